@@ -0,0 +1,171 @@
+// Package alerts evaluates per-symbol alert conditions against streamed
+// screener samples and fires notifications through pluggable sinks when a
+// condition transitions from not-matching to matching.
+package alerts
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+type Rule struct {
+	Condition string   `yaml:"condition"`
+	Sinks     []string `yaml:"sinks"`
+}
+
+type NotifierConfig struct {
+	SlackWebhook    string `yaml:"slackWebhook"`
+	DiscordWebhook  string `yaml:"discordWebhook"`
+	MQTTBroker      string `yaml:"mqttBroker"`
+	MQTTTopicPrefix string `yaml:"mqttTopicPrefix"`
+	DesktopEnabled  bool   `yaml:"desktopEnabled"`
+}
+
+type Sample struct {
+	Yes    *decimal.Decimal
+	No     *decimal.Decimal
+	Change float64
+}
+
+type Engine struct {
+	sinks map[string]Sink
+	mutex sync.Mutex
+	state map[string]bool
+}
+
+func NewEngine(notifiers NotifierConfig) *Engine {
+	return &Engine{
+		sinks: buildSinks(notifiers),
+		state: map[string]bool{},
+	}
+}
+
+// Check evaluates every rule declared for symbol against sample and fires the
+// configured sinks for rules that just transitioned into a matching state,
+// so a flapping order book doesn't spam the same alert every tick.
+func (engine *Engine) Check(symbol string, rules []Rule, sample Sample) {
+	for i, rule := range rules {
+		condition, err := parseCondition(rule.Condition)
+		if err != nil {
+			log.Printf("alerts: failed to parse condition %q for %s: %v", rule.Condition, symbol, err)
+			continue
+		}
+		matches := condition.evaluate(sample)
+		key := fmt.Sprintf("%s#%d", symbol, i)
+		engine.mutex.Lock()
+		wasMatching := engine.state[key]
+		engine.state[key] = matches
+		engine.mutex.Unlock()
+		if matches && !wasMatching {
+			engine.fire(symbol, rule, sample)
+		}
+	}
+}
+
+func (engine *Engine) fire(symbol string, rule Rule, sample Sample) {
+	message := fmt.Sprintf(
+		"%s: %s (yes=%s no=%s change=%+.2f%%)",
+		symbol,
+		rule.Condition,
+		decimalString(sample.Yes),
+		decimalString(sample.No),
+		sample.Change,
+	)
+	for _, name := range rule.Sinks {
+		sink, ok := engine.sinks[name]
+		if !ok {
+			log.Printf("alerts: unknown sink %q referenced by %s", name, symbol)
+			continue
+		}
+		if err := sink.Send(symbol, message); err != nil {
+			log.Printf("alerts: failed to send via %s: %v", name, err)
+		}
+	}
+}
+
+func decimalString(d *decimal.Decimal) string {
+	if d == nil {
+		return "N/A"
+	}
+	return d.StringFixed(2)
+}
+
+type clause struct {
+	field string
+	op    string
+	value float64
+}
+
+type condition struct {
+	clauses []clause
+}
+
+var clausePattern = regexp.MustCompile(`^\s*(yes|no|change)\s*(<=|>=|==|<|>)\s*(-?[0-9.]+)%?\s*$`)
+
+// parseCondition accepts a conjunction of clauses such as
+// "yes < 0.30 and change < -1%", one of {yes, no, change} compared against a
+// literal on each side of AND.
+func parseCondition(text string) (*condition, error) {
+	normalized := strings.ToLower(text)
+	parts := strings.Split(normalized, " and ")
+	clauses := make([]clause, 0, len(parts))
+	for _, part := range parts {
+		matches := clausePattern.FindStringSubmatch(part)
+		if matches == nil {
+			return nil, fmt.Errorf("unrecognized condition clause: %q", strings.TrimSpace(part))
+		}
+		value, err := strconv.ParseFloat(matches[3], 64)
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, clause{field: matches[1], op: matches[2], value: value})
+	}
+	return &condition{clauses: clauses}, nil
+}
+
+func (condition *condition) evaluate(sample Sample) bool {
+	for _, clause := range condition.clauses {
+		if !clause.evaluate(sample) {
+			return false
+		}
+	}
+	return true
+}
+
+func (clause clause) evaluate(sample Sample) bool {
+	var actual float64
+	switch clause.field {
+	case "yes":
+		if sample.Yes == nil {
+			return false
+		}
+		actual, _ = sample.Yes.Float64()
+	case "no":
+		if sample.No == nil {
+			return false
+		}
+		actual, _ = sample.No.Float64()
+	case "change":
+		actual = sample.Change
+	}
+	switch clause.op {
+	case "<":
+		return actual < clause.value
+	case "<=":
+		return actual <= clause.value
+	case ">":
+		return actual > clause.value
+	case ">=":
+		return actual >= clause.value
+	case "==":
+		return actual == clause.value
+	default:
+		return false
+	}
+}
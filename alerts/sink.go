@@ -0,0 +1,86 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/gen2brain/beeep"
+)
+
+// Sink delivers an alert message for symbol to a notification channel.
+type Sink interface {
+	Send(symbol, message string) error
+}
+
+func buildSinks(config NotifierConfig) map[string]Sink {
+	sinks := map[string]Sink{}
+	if config.SlackWebhook != "" {
+		sinks["slack"] = webhookSink{url: config.SlackWebhook}
+	}
+	if config.DiscordWebhook != "" {
+		sinks["discord"] = webhookSink{url: config.DiscordWebhook}
+	}
+	if config.MQTTBroker != "" {
+		sinks["mqtt"] = newMQTTSink(config.MQTTBroker, config.MQTTTopicPrefix)
+	}
+	if config.DesktopEnabled {
+		sinks["desktop"] = desktopSink{}
+	}
+	return sinks
+}
+
+type webhookSink struct {
+	url string
+}
+
+func (sink webhookSink) Send(symbol, message string) error {
+	body, err := json.Marshal(map[string]string{"text": message})
+	if err != nil {
+		return err
+	}
+	response, err := http.Post(sink.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", response.StatusCode)
+	}
+	return nil
+}
+
+type mqttSink struct {
+	client      mqtt.Client
+	topicPrefix string
+}
+
+func newMQTTSink(broker string, topicPrefix string) mqttSink {
+	options := mqtt.NewClientOptions().AddBroker(broker).SetClientID("screener-alerts")
+	client := mqtt.NewClient(options)
+	token := client.Connect()
+	go func() {
+		if token.Wait() && token.Error() != nil {
+			fmt.Printf("alerts: failed to connect to MQTT broker %s: %v\n", broker, token.Error())
+		}
+	}()
+	if topicPrefix == "" {
+		topicPrefix = "screener"
+	}
+	return mqttSink{client: client, topicPrefix: topicPrefix}
+}
+
+func (sink mqttSink) Send(symbol, message string) error {
+	topic := fmt.Sprintf("%s/%s", sink.topicPrefix, symbol)
+	token := sink.client.Publish(topic, 0, false, message)
+	token.Wait()
+	return token.Error()
+}
+
+type desktopSink struct{}
+
+func (desktopSink) Send(symbol, message string) error {
+	return beeep.Notify(fmt.Sprintf("Screener Alert: %s", symbol), message, "")
+}
@@ -0,0 +1,260 @@
+// Package backtest replays historical Polymarket daily up/down markets
+// against historical Yahoo closes to evaluate the screener's entry
+// heuristic as a trading strategy.
+package backtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/encratite/gamma"
+	"github.com/encratite/yahoo"
+	"github.com/shopspring/decimal"
+)
+
+type Symbol struct {
+	Symbol string
+	Yahoo  string
+}
+
+type Config struct {
+	From         time.Time
+	To           time.Time
+	Symbols      []Symbol
+	GoodPriceMax decimal.Decimal
+	StakeSize    decimal.Decimal
+}
+
+type Trade struct {
+	Symbol string
+	Date   time.Time
+	Side   string
+	Price  decimal.Decimal
+	Won    bool
+	PnL    decimal.Decimal
+}
+
+type Summary struct {
+	Trades      []Trade
+	HitRate     decimal.Decimal
+	TotalPnL    decimal.Decimal
+	Sharpe      float64
+	MaxDrawdown decimal.Decimal
+}
+
+// Run walks every day in [config.From, config.To] for every configured
+// symbol, simulates a trade whenever the entry heuristic would have fired,
+// and returns an aggregate summary.
+func Run(config Config) (*Summary, error) {
+	trades := []Trade{}
+	for date := config.From; !date.After(config.To); date = date.AddDate(0, 0, 1) {
+		for _, symbol := range config.Symbols {
+			trade, err := simulateDay(symbol, date, config)
+			if err != nil {
+				log.Printf("backtest: skipping %s on %s: %v", symbol.Symbol, date.Format("2006-01-02"), err)
+				continue
+			}
+			if trade != nil {
+				trades = append(trades, *trade)
+			}
+		}
+	}
+	return summarize(trades), nil
+}
+
+func simulateDay(symbol Symbol, date time.Time, config Config) (*Trade, error) {
+	slug := marketSlug(symbol.Symbol, date)
+	market, err := gamma.GetMarket(slug)
+	if err != nil || market.Slug == "" {
+		return nil, fmt.Errorf("market not found: %s", slug)
+	}
+	assetIDs := gamma.GetAssetIDs([]gamma.Market{market})
+	if len(assetIDs) == 0 {
+		return nil, fmt.Errorf("no asset IDs for %s", slug)
+	}
+
+	yahooSymbol := symbol.Yahoo
+	if yahooSymbol == "" {
+		yahooSymbol = symbol.Symbol
+	}
+	change, err := historicalChange(yahooSymbol, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve historical close: %w", err)
+	}
+
+	side := "yes"
+	if change < 0 {
+		side = "no"
+	}
+	price, err := entryPrice(assetIDs[0], date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve historical price: %w", err)
+	}
+	if side == "no" {
+		price = decimal.NewFromInt(1).Sub(price)
+	}
+	if price.GreaterThan(config.GoodPriceMax) {
+		return nil, nil
+	}
+
+	won, err := resolvedSide(market, side)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine settlement: %w", err)
+	}
+	shares := config.StakeSize.Div(price)
+	pnl := config.StakeSize.Neg()
+	if won {
+		pnl = shares.Sub(config.StakeSize)
+	}
+	return &Trade{
+		Symbol: symbol.Symbol,
+		Date:   date,
+		Side:   side,
+		Price:  price,
+		Won:    won,
+		PnL:    pnl,
+	}, nil
+}
+
+func marketSlug(symbol string, date time.Time) string {
+	lowerSymbol := strings.ToLower(symbol)
+	month := strings.ToLower(date.Month().String())
+	return fmt.Sprintf("%s-up-or-down-on-%s-%d-%d", lowerSymbol, month, date.Day(), date.Year())
+}
+
+// historicalChange derives the open-to-close move for date from the daily
+// OHLC window yahoo.GetFinanceData returns, mirroring the live GetChange
+// heuristic the screener uses for "today".
+func historicalChange(symbol string, date time.Time) (float64, error) {
+	from := date.AddDate(0, 0, -1).Unix()
+	to := date.AddDate(0, 0, 1).Unix()
+	data, err := yahoo.GetFinanceData(symbol, from, to, yahoo.Daily)
+	if err != nil {
+		return 0, err
+	}
+	if len(data.Chart.Result) == 0 {
+		return 0, fmt.Errorf("no finance data for %s on %s", symbol, date.Format("2006-01-02"))
+	}
+	result := data.Chart.Result[0]
+	if len(result.Indicators.Quote) == 0 {
+		return 0, fmt.Errorf("no quotes for %s on %s", symbol, date.Format("2006-01-02"))
+	}
+	quote := result.Indicators.Quote[0]
+	for i, timestamp := range result.Timestamp {
+		if !sameDay(time.Unix(timestamp, 0).UTC(), date) {
+			continue
+		}
+		if i >= len(quote.Open) || i >= len(quote.Close) || quote.Open[i] == 0 {
+			return 0, fmt.Errorf("no open price for %s on %s", symbol, date.Format("2006-01-02"))
+		}
+		return (quote.Close[i] - quote.Open[i]) / quote.Open[i] * 100, nil
+	}
+	return 0, fmt.Errorf("no matching trading day for %s on %s", symbol, date.Format("2006-01-02"))
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// priceHistoryFidelityMinutes requests one sample per hour; the first
+// sample at or after date's start stands in for "the last observed yes/no
+// price" described in the backlog request, applied at entry time.
+const priceHistoryFidelityMinutes = 60
+
+func entryPrice(assetID string, date time.Time) (decimal.Decimal, error) {
+	history, err := gamma.GetPriceHistory(assetID, date, priceHistoryFidelityMinutes)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if len(history.History) == 0 {
+		return decimal.Zero, fmt.Errorf("no price history for asset %s", assetID)
+	}
+	return decimal.NewFromFloat(history.History[0].Price), nil
+}
+
+// resolvedSide parses the gamma API's stringified Outcomes/OutcomePrices
+// arrays (e.g. `["Yes","No"]` / `["1","0"]`) to determine whether side won,
+// i.e. settled at a price of 1.
+func resolvedSide(market gamma.Market, side string) (bool, error) {
+	var outcomes []string
+	if err := json.Unmarshal([]byte(market.Outcomes), &outcomes); err != nil {
+		return false, fmt.Errorf("failed to parse outcomes for %s: %w", market.Slug, err)
+	}
+	var prices []string
+	if err := json.Unmarshal([]byte(market.OutcomePrices), &prices); err != nil {
+		return false, fmt.Errorf("failed to parse outcome prices for %s: %w", market.Slug, err)
+	}
+	for i, outcome := range outcomes {
+		if i >= len(prices) || !strings.EqualFold(outcome, side) {
+			continue
+		}
+		price, err := decimal.NewFromString(prices[i])
+		if err != nil {
+			return false, err
+		}
+		return price.Equal(decimal.NewFromInt(1)), nil
+	}
+	return false, fmt.Errorf("outcome %q not found for %s", side, market.Slug)
+}
+
+func summarize(trades []Trade) *Summary {
+	summary := &Summary{Trades: trades}
+	if len(trades) == 0 {
+		return summary
+	}
+	wins := 0
+	total := decimal.Zero
+	returns := make([]float64, len(trades))
+	for i, trade := range trades {
+		total = total.Add(trade.PnL)
+		if trade.Won {
+			wins++
+		}
+		returns[i], _ = trade.PnL.Float64()
+	}
+	summary.TotalPnL = total
+	summary.HitRate = decimal.NewFromInt(int64(wins)).Div(decimal.NewFromInt(int64(len(trades))))
+	summary.Sharpe = sharpeRatio(returns)
+	summary.MaxDrawdown = maxDrawdown(trades)
+	return summary
+}
+
+func sharpeRatio(returns []float64) float64 {
+	mean := 0.0
+	for _, value := range returns {
+		mean += value
+	}
+	mean /= float64(len(returns))
+	variance := 0.0
+	for _, value := range returns {
+		variance += (value - mean) * (value - mean)
+	}
+	variance /= float64(len(returns))
+	stdDev := math.Sqrt(variance)
+	if stdDev == 0 {
+		return 0
+	}
+	return mean / stdDev * math.Sqrt(float64(len(returns)))
+}
+
+func maxDrawdown(trades []Trade) decimal.Decimal {
+	peak := decimal.Zero
+	cumulative := decimal.Zero
+	worst := decimal.Zero
+	for _, trade := range trades {
+		cumulative = cumulative.Add(trade.PnL)
+		if cumulative.GreaterThan(peak) {
+			peak = cumulative
+		}
+		if drawdown := peak.Sub(cumulative); drawdown.GreaterThan(worst) {
+			worst = drawdown
+		}
+	}
+	return worst
+}
@@ -0,0 +1,45 @@
+package backtest
+
+import (
+	"encoding/csv"
+	"os"
+)
+
+// WriteCSV writes one row per trade to path, for use alongside the printed
+// summary table.
+func WriteCSV(path string, trades []Trade) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	header := []string{"Symbol", "Date", "Side", "Price", "Won", "PnL"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, trade := range trades {
+		row := []string{
+			trade.Symbol,
+			trade.Date.Format("2006-01-02"),
+			trade.Side,
+			trade.Price.StringFixed(4),
+			boolString(trade.Won),
+			trade.PnL.StringFixed(2),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func boolString(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/encratite/screener/backtest"
+	"github.com/olekukonko/tablewriter"
+	"github.com/shopspring/decimal"
+)
+
+func runBacktestCommand(args []string) {
+	flagSet := flag.NewFlagSet("backtest", flag.ExitOnError)
+	from := flagSet.String("from", "", "Start date of the backtest (YYYY-MM-DD)")
+	to := flagSet.String("to", "", "End date of the backtest (YYYY-MM-DD)")
+	stake := flagSet.String("stake", "100", "Stake size per trade")
+	goodPriceMax := flagSet.String("goodPriceMax", goodPriceMaxString, "Maximum entry price to take a position")
+	csvPath := flagSet.String("csv", "", "Optional path to write a per-trade CSV report to")
+	flagSet.Parse(args)
+
+	fromDate, err := time.Parse("2006-01-02", *from)
+	if err != nil {
+		log.Fatalf("Invalid -from date: %v", err)
+	}
+	toDate, err := time.Parse("2006-01-02", *to)
+	if err != nil {
+		log.Fatalf("Invalid -to date: %v", err)
+	}
+
+	config := backtest.Config{
+		From:         fromDate,
+		To:           toDate,
+		Symbols:      backtestSymbols(),
+		GoodPriceMax: mustParseDecimal(*goodPriceMax),
+		StakeSize:    mustParseDecimal(*stake),
+	}
+	summary, err := backtest.Run(config)
+	if err != nil {
+		log.Fatalf("Backtest failed: %v", err)
+	}
+	if *csvPath != "" {
+		if err := backtest.WriteCSV(*csvPath, summary.Trades); err != nil {
+			log.Fatalf("Failed to write CSV report: %v", err)
+		}
+	}
+	printBacktestSummary(summary)
+}
+
+func backtestSymbols() []backtest.Symbol {
+	symbols := make([]backtest.Symbol, len(configuration.Symbols))
+	for i, symbol := range configuration.Symbols {
+		symbols[i] = backtest.Symbol{Symbol: symbol.Symbol, Yahoo: symbol.Yahoo}
+	}
+	return symbols
+}
+
+func printBacktestSummary(summary *backtest.Summary) {
+	header := []string{"Trades", "Hit Rate", "Total P&L", "Sharpe", "Max Drawdown"}
+	row := []string{
+		fmt.Sprintf("%d", len(summary.Trades)),
+		summary.HitRate.Mul(decimal.NewFromInt(100)).StringFixed(1) + "%",
+		summary.TotalPnL.StringFixed(2),
+		fmt.Sprintf("%.2f", summary.Sharpe),
+		summary.MaxDrawdown.StringFixed(2),
+	}
+	fmt.Printf("\n")
+	table := tablewriter.NewTable(os.Stdout)
+	table.Header(header)
+	table.Bulk([][]string{row})
+	table.Render()
+	fmt.Printf("\n")
+}
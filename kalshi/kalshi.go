@@ -0,0 +1,76 @@
+// Package kalshi is a minimal client for Kalshi's public REST API, following
+// the same plain net/http + encoding/json approach as the gamma and yahoo
+// packages this project already depends on.
+package kalshi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const baseURL = "https://trading-api.kalshi.com/trade-api/v2"
+
+// pollInterval paces SubscribeToMarkets. Kalshi's live order book feed is a
+// websocket that requires signed, API-key-authenticated requests; until that
+// signing is wired up here, callers get a REST poll at this cadence instead.
+const pollInterval = 5 * time.Second
+
+type Market struct {
+	Ticker string `json:"ticker"`
+	YesAsk int    `json:"yes_ask"`
+	NoAsk  int    `json:"no_ask"`
+}
+
+type marketResponse struct {
+	Market Market `json:"market"`
+}
+
+func GetMarket(ticker string) (Market, error) {
+	url := fmt.Sprintf("%s/markets/%s", baseURL, ticker)
+	response, err := http.Get(url)
+	if err != nil {
+		return Market{}, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return Market{}, fmt.Errorf("kalshi: unexpected status %d for %s", response.StatusCode, ticker)
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return Market{}, err
+	}
+	var parsed marketResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return Market{}, err
+	}
+	return parsed.Market, nil
+}
+
+type OrderbookMessage struct {
+	Ticker string
+	YesAsk int
+	NoAsk  int
+}
+
+// SubscribeToMarkets polls GetMarket for every ticker once per pollInterval,
+// invoking callback with the latest quote for each. It mirrors
+// gamma.SubscribeToMarkets: callback returning false stops the subscription.
+func SubscribeToMarkets(tickers []string, callback func(OrderbookMessage) bool) {
+	for {
+		for _, ticker := range tickers {
+			market, err := GetMarket(ticker)
+			if err != nil {
+				fmt.Printf("kalshi: failed to poll %s: %v\n", ticker, err)
+				continue
+			}
+			message := OrderbookMessage{Ticker: market.Ticker, YesAsk: market.YesAsk, NoAsk: market.NoAsk}
+			if !callback(message) {
+				return
+			}
+		}
+		time.Sleep(pollInterval)
+	}
+}
@@ -6,12 +6,12 @@ import (
 	"log"
 	"math"
 	"os"
-	"slices"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/encratite/commons"
-	"github.com/encratite/gamma"
+	"github.com/encratite/screener/alerts"
+	"github.com/encratite/screener/provider"
 	"github.com/encratite/yahoo"
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
@@ -22,108 +22,248 @@ import (
 const (
 	configurationPath = "configuration/configuration.yaml"
 	goodPriceMaxString = "0.75"
-	enableSpreadColors = false
 )
 
+var spreadHighlightMin = decimal.NewFromFloat(0.05)
+
 var configuration *Configuration
+var alertEngine *alerts.Engine
+var alertEngineOnce sync.Once
+
+// getAlertEngine lazily constructs the alert engine (and, transitively, its
+// sinks such as the MQTT client) on first use, so subcommands that never
+// check alerts, like backtest and rebalance, don't pay for it at startup.
+func getAlertEngine() *alerts.Engine {
+	alertEngineOnce.Do(func() {
+		alertEngine = alerts.NewEngine(configuration.Notifiers)
+	})
+	return alertEngine
+}
 
 type Configuration struct {
-	Symbols []ScreenerSymbol `yaml:"symbols"`
+	Symbols             []ScreenerSymbol      `yaml:"symbols"`
+	Notifiers           alerts.NotifierConfig `yaml:"notifiers"`
+	TotalCapital        string                `yaml:"totalCapital"`
+	EnableSpreadColors  bool                  `yaml:"enableSpreadColors"`
+	WatchRefreshSeconds int                   `yaml:"watchRefreshSeconds"`
 }
 
 type ScreenerSymbol struct {
-	Symbol string `yaml:"symbol"`
-	Yahoo string `yaml:"yahoo"`
+	Symbol          string        `yaml:"symbol"`
+	Yahoo           string        `yaml:"yahoo"`
+	Alerts          []alerts.Rule `yaml:"alerts"`
+	Weight          string        `yaml:"weight"`
+	Provider        string        `yaml:"provider"`
+	CompareProvider string        `yaml:"compareProvider"`
 }
 
 type symbolData struct {
 	symbol string
 	yahoo string
+	provider string
 	yes *decimal.Decimal
 	no *decimal.Decimal
 	spread *decimal.Decimal
 	change float64
 }
 
+func symbolProviderName(symbol ScreenerSymbol) string {
+	if symbol.Provider == "" {
+		return "polymarket"
+	}
+	return symbol.Provider
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "backtest" {
+		loadConfiguration()
+		runBacktestCommand(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "rebalance" {
+		loadConfiguration()
+		runRebalanceCommand(os.Args[2:])
+		return
+	}
 	tomorrow := flag.Bool("tomorrow", false, "Run screener for tomorrow's daily markets, for use after session close")
+	watch := flag.Bool("watch", false, "Enter interactive live-updating TUI mode instead of printing a single table")
 	flag.Parse()
 	loadConfiguration()
-	runScreener(*tomorrow)
+	if *watch {
+		runWatch(*tomorrow)
+	} else {
+		runScreener(*tomorrow)
+	}
 }
 
 func loadConfiguration() {
-	configuration = commons.LoadConfiguration(configurationPath, configuration)
+	configuration = commons.LoadConfiguration[Configuration](configurationPath)
 }
 
-func runScreener(tomorrow bool) {
-	markets := []gamma.Market{}
-	date := time.Now()
-	if tomorrow {
-		date = date.AddDate(0, 0, 1)
-	}
-	for _, symbol := range configuration.Symbols {
-		lowerSymbol := strings.ToLower(symbol.Symbol)
-		month := strings.ToLower(date.Month().String())
-		slug := fmt.Sprintf("%s-up-or-down-on-%s-%d-%d", lowerSymbol, month, date.Day(), date.Year())
-		market, err := gamma.GetMarket(slug)
-		if err != nil || market.Slug == "" {
-			log.Fatalf("Failed to retrieve market %s for symbol %s", slug, symbol.Symbol)
+// resolveProviderMarkets resolves each symbol's daily market on its
+// configured provider and groups the symbol indices by provider name so
+// each venue can be subscribed to in a single batched call.
+func resolveProviderMarkets(symbols []ScreenerSymbol, date time.Time) ([]provider.Market, map[string][]int) {
+	markets := make([]provider.Market, len(symbols))
+	groups := map[string][]int{}
+	for i, symbol := range symbols {
+		providerName := symbolProviderName(symbol)
+		market, err := provider.Get(providerName).ResolveDailyMarket(symbol.Symbol, date)
+		if err != nil {
+			log.Fatalf("Failed to resolve market for %s: %v", symbol.Symbol, err)
 		}
-		markets = append(markets, market)
+		markets[i] = market
+		groups[providerName] = append(groups[providerName], i)
 	}
-	assetIDs := gamma.GetAssetIDs(markets)
+	return markets, groups
+}
+
+func flattenAssetIDs(markets []provider.Market, indices []int) ([]string, map[string]int) {
+	assetIDs := []string{}
+	indexByAssetID := map[string]int{}
+	for _, index := range indices {
+		for _, assetID := range markets[index].AssetIDs {
+			assetIDs = append(assetIDs, assetID)
+			indexByAssetID[assetID] = index
+		}
+	}
+	return assetIDs, indexByAssetID
+}
+
+func runScreener(tomorrow bool) {
+	symbols := collectSnapshot(tomorrow)
+	printTable(symbols)
+}
+
+func collectSnapshot(tomorrow bool) []symbolData {
+	date := watchDate(tomorrow)
+	markets, groups := resolveProviderMarkets(configuration.Symbols, date)
+	changes := resolveChanges(configuration.Symbols)
+	secondaryQuotes := resolveCrossVenueQuotes(configuration.Symbols, date, changes)
 	symbols := make([]symbolData, len(configuration.Symbols))
-	symbolCount := 0
-	gamma.SubscribeToMarkets(assetIDs,  func(message gamma.BookMessage) bool {
-		if message.EventType == gamma.BookEvent {
-			index := slices.Index(assetIDs, message.AssetID)
-			if index >= 0 {
-				symbol := configuration.Symbols[index]
-				yes := getOrderSummary(message.Asks)
-				no := getOrderSummary(message.Bids)
-				if no != nil {
-					*no = decimal.NewFromInt(1).Sub(*no)
-				}
-				yahooSymbol := symbol.Symbol
-				if symbol.Yahoo != "" {
-					yahooSymbol = symbol.Yahoo
-				}
-				change, err := yahoo.GetChange(yahooSymbol)
-				if err != nil {
-					log.Fatalf("Failed to retrieve last close for %s: %v", symbol.Symbol, err)
-				}
-				data := symbolData{
-					symbol: symbol.Symbol,
-					yahoo: symbol.Yahoo,
-					yes: yes,
-					no: no,
-					change: change,
+	var mutex sync.Mutex
+	var waitGroup sync.WaitGroup
+	for providerName, indices := range groups {
+		waitGroup.Add(1)
+		go func(providerName string, indices []int) {
+			defer waitGroup.Done()
+			marketProvider := provider.Get(providerName)
+			assetIDs, indexByAssetID := flattenAssetIDs(markets, indices)
+			receivedCount := 0
+			marketProvider.SubscribeBook(assetIDs, func(update provider.BookUpdate) bool {
+				index, ok := indexByAssetID[update.AssetID]
+				if !ok {
+					fmt.Printf("Unknown asset ID: %s\n", update.AssetID)
+					return receivedCount < len(indices)
 				}
+				data := buildSymbolData(marketProvider, providerName, index, update, changes[index], secondaryQuotes[index])
+				mutex.Lock()
 				symbols[index] = data
-				symbolCount++
-			} else {
-				fmt.Printf("Unknown asset ID: %s\n", message.AssetID)
+				mutex.Unlock()
+				receivedCount++
+				return receivedCount < len(indices)
+			})
+		}(providerName, indices)
+	}
+	waitGroup.Wait()
+	return symbols
+}
+
+// resolveChanges looks up each symbol's Yahoo change once, concurrently,
+// ahead of the book subscriptions, so buildSymbolData's per-update callback
+// never has to block on an HTTP round trip.
+func resolveChanges(symbols []ScreenerSymbol) []float64 {
+	changes := make([]float64, len(symbols))
+	var waitGroup sync.WaitGroup
+	for index, symbol := range symbols {
+		waitGroup.Add(1)
+		go func(index int, symbol ScreenerSymbol) {
+			defer waitGroup.Done()
+			yahooSymbol := symbol.Symbol
+			if symbol.Yahoo != "" {
+				yahooSymbol = symbol.Yahoo
+			}
+			change, err := yahoo.GetChange(yahooSymbol)
+			if err != nil {
+				log.Fatalf("Failed to retrieve last close for %s: %v", symbol.Symbol, err)
 			}
-			return symbolCount < len(configuration.Symbols)
+			changes[index] = change
+		}(index, symbol)
+	}
+	waitGroup.Wait()
+	return changes
+}
+
+func buildSymbolData(marketProvider provider.MarketProvider, providerName string, index int, update provider.BookUpdate, change float64, secondary *decimal.Decimal) symbolData {
+	symbol := configuration.Symbols[index]
+	yes, no := marketProvider.NormalizedYesNo(update)
+	data := symbolData{
+		symbol:   symbol.Symbol,
+		yahoo:    symbol.Yahoo,
+		provider: providerName,
+		yes:      yes,
+		no:       no,
+		change:   change,
+	}
+	primary := yes
+	if change < 0 {
+		primary = no
+	}
+	data.spread = crossVenueSpread(primary, secondary)
+	getAlertEngine().Check(symbol.Symbol, symbol.Alerts, alerts.Sample{Yes: yes, No: no, Change: change})
+	return data
+}
+
+// resolveCrossVenueQuotes resolves the same-direction contract price on
+// every symbol's CompareProvider once, concurrently, rather than dialing a
+// fresh subscription inline on every book update from the primary provider.
+func resolveCrossVenueQuotes(symbols []ScreenerSymbol, date time.Time, changes []float64) []*decimal.Decimal {
+	quotes := make([]*decimal.Decimal, len(symbols))
+	var waitGroup sync.WaitGroup
+	for index, symbol := range symbols {
+		if symbol.CompareProvider == "" {
+			continue
+		}
+		waitGroup.Add(1)
+		go func(index int, symbol ScreenerSymbol) {
+			defer waitGroup.Done()
+			quotes[index] = fetchCrossVenueQuote(symbol, date, changes[index])
+		}(index, symbol)
+	}
+	waitGroup.Wait()
+	return quotes
+}
+
+func fetchCrossVenueQuote(symbol ScreenerSymbol, date time.Time, change float64) *decimal.Decimal {
+	compareProvider := provider.Get(symbol.CompareProvider)
+	market, err := compareProvider.ResolveDailyMarket(symbol.Symbol, date)
+	if err != nil {
+		return nil
+	}
+	var quote *decimal.Decimal
+	receivedCount := 0
+	compareProvider.SubscribeBook(market.AssetIDs, func(update provider.BookUpdate) bool {
+		yes, no := compareProvider.NormalizedYesNo(update)
+		if change < 0 {
+			quote = no
 		} else {
-			return false
+			quote = yes
 		}
+		receivedCount++
+		return receivedCount < 1
 	})
-	printTable(symbols)
+	return quote
 }
 
-func getOrderSummary(summary []gamma.OrderSummary) *decimal.Decimal {
-	if len(summary) > 0 {
-		priceString :=  summary[len(summary) - 1].Price
-		price, err := decimal.NewFromString(priceString)
-		if err != nil {
-			log.Fatalf("Failed to parse price: %s", priceString)
-		}
-		return &price
-	} else {
+// crossVenueSpread compares the primary provider's price against the
+// pre-fetched same-direction quote from the symbol's CompareProvider,
+// highlighting when the position is meaningfully cheaper on one venue.
+func crossVenueSpread(primary *decimal.Decimal, secondary *decimal.Decimal) *decimal.Decimal {
+	if primary == nil || secondary == nil {
 		return nil
 	}
+	spread := primary.Sub(*secondary).Abs()
+	return &spread
 }
 
 func mustParseDecimal(value string) decimal.Decimal {
@@ -134,6 +274,14 @@ func mustParseDecimal(value string) decimal.Decimal {
 	return output
 }
 
+func decimalOrNA(d *decimal.Decimal) string {
+	if d != nil {
+		return d.StringFixed(2)
+	} else {
+		return "N/A"
+	}
+}
+
 func printTable(symbols []symbolData) {
 	goodPriceMax := mustParseDecimal(goodPriceMaxString)
 	header := []string{
@@ -141,23 +289,18 @@ func printTable(symbols []symbolData) {
 		"Yes Price",
 		"No Price",
 		"Change",
+		"Provider",
+		"Spread",
 	}
 	rows := [][]string{}
 	for _, data := range symbols {
-		getDecimalString := func (d *decimal.Decimal) string {
-			if d != nil {
-				return d.StringFixed(2)
-			} else {
-				return "N/A"
-			}
-		}
 		green := color.New(color.FgGreen).SprintFunc()
 		red := color.New(color.FgRed).SprintFunc()
-		yesString := getDecimalString(data.yes)
+		yesString := decimalOrNA(data.yes)
 		if data.change > 0.0 && data.yes != nil && data.yes.LessThanOrEqual(goodPriceMax) {
 			yesString = green(yesString)
 		}
-		noString := getDecimalString(data.no)
+		noString := decimalOrNA(data.no)
 		if data.change < 0.0 && data.no != nil && data.no.LessThanOrEqual(goodPriceMax) {
 			noString = green(noString)
 		}
@@ -172,11 +315,17 @@ func printTable(symbols []symbolData) {
 		} else {
 			changeString = "-"
 		}
+		spreadString := decimalOrNA(data.spread)
+		if configuration.EnableSpreadColors && data.spread != nil && data.spread.GreaterThanOrEqual(spreadHighlightMin) {
+			spreadString = green(spreadString)
+		}
 		row := []string{
 			data.symbol,
 			yesString,
 			noString,
 			changeString,
+			data.provider,
+			spreadString,
 		}
 		rows = append(rows, row)
 	}
@@ -185,6 +334,7 @@ func printTable(symbols []symbolData) {
 		tw.AlignRight,
 		tw.AlignRight,
 		tw.AlignRight,
+		tw.AlignDefault,
 		tw.AlignRight,
 	}
 	tableConfig := tablewriter.WithConfig(tablewriter.Config{
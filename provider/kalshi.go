@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/encratite/screener/kalshi"
+	"github.com/shopspring/decimal"
+)
+
+// Kalshi resolves the equivalent daily equity-direction contract, e.g.
+// ticker "KXAAPLD-26JUL27". The yes/no sides of the up-or-down question are
+// both exposed on that single market, unlike Polymarket's separate assets.
+type Kalshi struct{}
+
+func NewKalshi() *Kalshi {
+	return &Kalshi{}
+}
+
+func (*Kalshi) Name() string {
+	return "kalshi"
+}
+
+func (*Kalshi) ResolveDailyMarket(symbol string, date time.Time) (Market, error) {
+	ticker := fmt.Sprintf("KX%sD-%s", strings.ToUpper(symbol), strings.ToUpper(date.Format("06Jan02")))
+	market, err := kalshi.GetMarket(ticker)
+	if err != nil || market.Ticker == "" {
+		return Market{}, fmt.Errorf("failed to retrieve market %s for symbol %s", ticker, symbol)
+	}
+	return Market{Provider: "kalshi", Symbol: symbol, Slug: market.Ticker, AssetIDs: []string{market.Ticker}}, nil
+}
+
+func (*Kalshi) SubscribeBook(assetIDs []string, callback func(BookUpdate) bool) {
+	kalshi.SubscribeToMarkets(assetIDs, func(message kalshi.OrderbookMessage) bool {
+		return callback(BookUpdate{AssetID: message.Ticker, Raw: message})
+	})
+}
+
+func (*Kalshi) NormalizedYesNo(update BookUpdate) (*decimal.Decimal, *decimal.Decimal) {
+	message, ok := update.Raw.(kalshi.OrderbookMessage)
+	if !ok {
+		return nil, nil
+	}
+	return kalshiCentsToDecimal(message.YesAsk), kalshiCentsToDecimal(message.NoAsk)
+}
+
+func kalshiCentsToDecimal(cents int) *decimal.Decimal {
+	if cents <= 0 {
+		return nil
+	}
+	price := decimal.NewFromInt(int64(cents)).Div(decimal.NewFromInt(100))
+	return &price
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/encratite/gamma"
+	"github.com/shopspring/decimal"
+)
+
+// Polymarket is the original provider, wrapping the gamma client that
+// runScreener used to call directly.
+type Polymarket struct{}
+
+func NewPolymarket() *Polymarket {
+	return &Polymarket{}
+}
+
+func (*Polymarket) Name() string {
+	return "polymarket"
+}
+
+func (*Polymarket) ResolveDailyMarket(symbol string, date time.Time) (Market, error) {
+	lowerSymbol := strings.ToLower(symbol)
+	month := strings.ToLower(date.Month().String())
+	slug := fmt.Sprintf("%s-up-or-down-on-%s-%d-%d", lowerSymbol, month, date.Day(), date.Year())
+	market, err := gamma.GetMarket(slug)
+	if err != nil || market.Slug == "" {
+		return Market{}, fmt.Errorf("failed to retrieve market %s for symbol %s", slug, symbol)
+	}
+	assetIDs := gamma.GetAssetIDs([]gamma.Market{market})
+	return Market{Provider: "polymarket", Symbol: symbol, Slug: market.Slug, AssetIDs: assetIDs}, nil
+}
+
+func (*Polymarket) SubscribeBook(assetIDs []string, callback func(BookUpdate) bool) {
+	gamma.SubscribeToMarkets(assetIDs, func(message gamma.BookMessage) bool {
+		if message.EventType != gamma.BookEvent {
+			return false
+		}
+		return callback(BookUpdate{AssetID: message.AssetID, Raw: message})
+	})
+}
+
+func (*Polymarket) NormalizedYesNo(update BookUpdate) (*decimal.Decimal, *decimal.Decimal) {
+	message, ok := update.Raw.(gamma.BookMessage)
+	if !ok {
+		return nil, nil
+	}
+	yes := lastOrderPrice(message.Asks)
+	no := lastOrderPrice(message.Bids)
+	if no != nil {
+		complement := decimal.NewFromInt(1).Sub(*no)
+		no = &complement
+	}
+	return yes, no
+}
+
+func lastOrderPrice(summary []gamma.OrderSummary) *decimal.Decimal {
+	if len(summary) == 0 {
+		return nil
+	}
+	priceString := summary[len(summary)-1].Price
+	price, err := decimal.NewFromString(priceString)
+	if err != nil {
+		return nil
+	}
+	return &price
+}
@@ -0,0 +1,42 @@
+// Package provider abstracts the prediction-market venue behind the
+// screener so the same daily up/down contract can be sourced from more than
+// one exchange.
+package provider
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type Market struct {
+	Provider string
+	Symbol   string
+	Slug     string
+	AssetIDs []string
+}
+
+// BookUpdate carries a venue-specific order book payload in Raw; only the
+// MarketProvider that produced it knows how to read it back out.
+type BookUpdate struct {
+	AssetID string
+	Raw     any
+}
+
+type MarketProvider interface {
+	Name() string
+	ResolveDailyMarket(symbol string, date time.Time) (Market, error)
+	SubscribeBook(assetIDs []string, callback func(BookUpdate) bool)
+	NormalizedYesNo(update BookUpdate) (yes *decimal.Decimal, no *decimal.Decimal)
+}
+
+// Get returns the provider registered under name, falling back to
+// Polymarket for an empty name so existing configurations keep working.
+func Get(name string) MarketProvider {
+	switch name {
+	case "kalshi":
+		return NewKalshi()
+	default:
+		return NewPolymarket()
+	}
+}
@@ -0,0 +1,63 @@
+// Package rebalance sizes Polymarket positions for a watchlist treated as a
+// portfolio with target weights, given live yes/no prices.
+package rebalance
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+type Position struct {
+	Symbol string
+	Weight decimal.Decimal
+	Yes    *decimal.Decimal
+	No     *decimal.Decimal
+	Change float64
+}
+
+type Order struct {
+	Symbol string
+	Side   string
+	Price  decimal.Decimal
+	Shares decimal.Decimal
+	Amount decimal.Decimal
+}
+
+type Config struct {
+	TotalCapital decimal.Decimal
+	Threshold    decimal.Decimal
+	MaxAmount    decimal.Decimal
+}
+
+// Compute sizes one order per position that clears config.Threshold, going
+// long yes when change > 0 and long no when change < 0, capped at
+// config.MaxAmount per symbol.
+func Compute(positions []Position, config Config) []Order {
+	orders := []Order{}
+	thresholdAmount := config.TotalCapital.Mul(config.Threshold)
+	for _, position := range positions {
+		side := "yes"
+		price := position.Yes
+		if position.Change < 0 {
+			side = "no"
+			price = position.No
+		}
+		if price == nil || !price.IsPositive() {
+			continue
+		}
+		amount := config.TotalCapital.Mul(position.Weight)
+		if amount.GreaterThan(config.MaxAmount) {
+			amount = config.MaxAmount
+		}
+		if amount.LessThan(thresholdAmount) {
+			continue
+		}
+		orders = append(orders, Order{
+			Symbol: position.Symbol,
+			Side:   side,
+			Price:  *price,
+			Shares: amount.Div(*price),
+			Amount: amount,
+		})
+	}
+	return orders
+}
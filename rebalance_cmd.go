@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/encratite/screener/rebalance"
+	"github.com/olekukonko/tablewriter"
+)
+
+func runRebalanceCommand(args []string) {
+	flagSet := flag.NewFlagSet("rebalance", flag.ExitOnError)
+	tomorrow := flagSet.Bool("tomorrow", false, "Rebalance against tomorrow's daily markets, for use after session close")
+	threshold := flagSet.String("threshold", "0.01", "Minimum order size as a fraction of total capital")
+	maxAmount := flagSet.String("maxAmount", "", "Maximum dollar amount per symbol, defaults to totalCapital")
+	dryRun := flagSet.Bool("dryRun", true, "Print the intended order table instead of submitting orders")
+	flagSet.Parse(args)
+
+	totalCapital := mustParseDecimal(configuration.TotalCapital)
+	maxAmountDecimal := totalCapital
+	if *maxAmount != "" {
+		maxAmountDecimal = mustParseDecimal(*maxAmount)
+	}
+
+	positions := collectRebalancePositions(*tomorrow)
+	orders := rebalance.Compute(positions, rebalance.Config{
+		TotalCapital: totalCapital,
+		Threshold:    mustParseDecimal(*threshold),
+		MaxAmount:    maxAmountDecimal,
+	})
+	printRebalanceOrders(orders)
+	if !*dryRun {
+		log.Fatalf("Order submission is not implemented yet, rerun with -dryRun")
+	}
+}
+
+func collectRebalancePositions(tomorrow bool) []rebalance.Position {
+	symbols := collectSnapshot(tomorrow)
+	positions := make([]rebalance.Position, len(symbols))
+	for i, data := range symbols {
+		positions[i] = rebalance.Position{
+			Symbol: data.symbol,
+			Weight: mustParseDecimal(configuration.Symbols[i].Weight),
+			Yes:    data.yes,
+			No:     data.no,
+			Change: data.change,
+		}
+	}
+	return positions
+}
+
+func printRebalanceOrders(orders []rebalance.Order) {
+	header := []string{"Symbol", "Side", "Price", "Shares", "Amount"}
+	rows := [][]string{}
+	for _, order := range orders {
+		rows = append(rows, []string{
+			order.Symbol,
+			order.Side,
+			order.Price.StringFixed(2),
+			order.Shares.StringFixed(2),
+			order.Amount.StringFixed(2),
+		})
+	}
+	fmt.Printf("\n")
+	table := tablewriter.NewTable(os.Stdout)
+	table.Header(header)
+	table.Bulk(rows)
+	table.Render()
+	fmt.Printf("\n")
+}
@@ -0,0 +1,354 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/encratite/screener/alerts"
+	"github.com/encratite/screener/provider"
+	"github.com/encratite/yahoo"
+	"github.com/nsf/termbox-go"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultWatchRefreshSeconds is used when configuration.WatchRefreshSeconds
+// is unset. The refresh deadline is always a fraction of the interval, so a
+// slow Yahoo fetch is flagged before the next tick rather than possibly
+// overlapping it.
+const (
+	defaultWatchRefreshSeconds = 60
+	watchRefreshDeadlineFactor = 0.75
+)
+
+func watchRefreshInterval() time.Duration {
+	seconds := configuration.WatchRefreshSeconds
+	if seconds <= 0 {
+		seconds = defaultWatchRefreshSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func watchRefreshDeadline() time.Duration {
+	return time.Duration(float64(watchRefreshInterval()) * watchRefreshDeadlineFactor)
+}
+
+type watchScreen struct {
+	mutex      sync.Mutex
+	symbols    []symbolData
+	status     string
+	input      string
+	inputting  bool
+	removing   bool
+	date       time.Time
+	generation int
+	refreshing bool
+}
+
+func runWatch(tomorrow bool) {
+	screen := &watchScreen{date: watchDate(tomorrow)}
+
+	if err := termbox.Init(); err != nil {
+		log.Fatalf("Failed to initialize terminal UI: %v", err)
+	}
+	defer termbox.Close()
+
+	screen.resubscribe()
+	go screen.pollYahoo()
+
+	screen.render()
+	screen.eventLoop()
+}
+
+func watchDate(tomorrow bool) time.Time {
+	date := time.Now()
+	if tomorrow {
+		date = date.AddDate(0, 0, 1)
+	}
+	return date
+}
+
+// resubscribe rebuilds every provider subscription from the current
+// configuration.Symbols. It bumps screen.generation so goroutines started by
+// a previous call notice they're stale and exit instead of writing into a
+// now-shifted or now-removed row.
+func (screen *watchScreen) resubscribe() {
+	screen.mutex.Lock()
+	screen.generation++
+	generation := screen.generation
+	date := screen.date
+	symbolsSnapshot := append([]ScreenerSymbol{}, configuration.Symbols...)
+	symbols := make([]symbolData, len(symbolsSnapshot))
+	for i, symbol := range symbolsSnapshot {
+		symbols[i] = symbolData{symbol: symbol.Symbol, yahoo: symbol.Yahoo}
+	}
+	screen.symbols = symbols
+	screen.mutex.Unlock()
+
+	markets, groups := resolveProviderMarkets(symbolsSnapshot, date)
+	for providerName, indices := range groups {
+		go func(providerName string, indices []int) {
+			marketProvider := provider.Get(providerName)
+			assetIDs, indexByAssetID := flattenAssetIDs(markets, indices)
+			symbolByAssetID := make(map[string]string, len(indexByAssetID))
+			for assetID, index := range indexByAssetID {
+				symbolByAssetID[assetID] = symbolsSnapshot[index].Symbol
+			}
+			for !screen.isStale(generation) {
+				marketProvider.SubscribeBook(assetIDs, func(update provider.BookUpdate) bool {
+					if screen.isStale(generation) {
+						return false
+					}
+					if symbol, ok := symbolByAssetID[update.AssetID]; ok {
+						screen.applyUpdate(marketProvider, providerName, symbol, update)
+						screen.render()
+					}
+					return true
+				})
+			}
+		}(providerName, indices)
+	}
+}
+
+func (screen *watchScreen) isStale(generation int) bool {
+	screen.mutex.Lock()
+	defer screen.mutex.Unlock()
+	return screen.generation != generation
+}
+
+func (screen *watchScreen) pollYahoo() {
+	ticker := time.NewTicker(watchRefreshInterval())
+	defer ticker.Stop()
+	for range ticker.C {
+		screen.mutex.Lock()
+		busy := screen.refreshing
+		if !busy {
+			screen.refreshing = true
+		}
+		screen.mutex.Unlock()
+		if busy {
+			screen.setStatus("Skipping Yahoo refresh, previous cycle still in flight")
+			continue
+		}
+
+		done := make(chan bool, 1)
+		go func() {
+			screen.refreshChanges()
+			screen.mutex.Lock()
+			screen.refreshing = false
+			screen.mutex.Unlock()
+			done <- true
+		}()
+		select {
+		case <-done:
+		case <-time.After(watchRefreshDeadline()):
+			screen.setStatus("Yahoo refresh exceeded deadline, still running in background")
+		}
+	}
+}
+
+func (screen *watchScreen) refreshChanges() {
+	screen.mutex.Lock()
+	symbols := append([]ScreenerSymbol{}, configuration.Symbols...)
+	screen.mutex.Unlock()
+	for _, symbol := range symbols {
+		yahooSymbol := symbol.Symbol
+		if symbol.Yahoo != "" {
+			yahooSymbol = symbol.Yahoo
+		}
+		change, err := yahoo.GetChange(yahooSymbol)
+		if err != nil {
+			screen.setStatus(fmt.Sprintf("Failed to retrieve last close for %s: %v", symbol.Symbol, err))
+			continue
+		}
+		screen.mutex.Lock()
+		if index := screen.indexOf(symbol.Symbol); index >= 0 {
+			screen.symbols[index].change = change
+		}
+		screen.mutex.Unlock()
+	}
+	screen.render()
+}
+
+func (screen *watchScreen) applyUpdate(marketProvider provider.MarketProvider, providerName string, symbol string, update provider.BookUpdate) {
+	screen.mutex.Lock()
+	defer screen.mutex.Unlock()
+	index := screen.indexOf(symbol)
+	if index < 0 {
+		return
+	}
+	yes, no := marketProvider.NormalizedYesNo(update)
+	data := screen.symbols[index]
+	data.provider = providerName
+	data.yes = yes
+	data.no = no
+	screen.symbols[index] = data
+	getAlertEngine().Check(symbol, screen.alertRulesFor(symbol), alerts.Sample{Yes: yes, No: no, Change: data.change})
+}
+
+// indexOf looks up a symbol's current row by name rather than a captured
+// position, since add/remove can shift every row after it. Callers must
+// already hold screen.mutex.
+func (screen *watchScreen) indexOf(symbol string) int {
+	for i, data := range screen.symbols {
+		if data.symbol == symbol {
+			return i
+		}
+	}
+	return -1
+}
+
+func (screen *watchScreen) alertRulesFor(symbol string) []alerts.Rule {
+	for _, configured := range configuration.Symbols {
+		if configured.Symbol == symbol {
+			return configured.Alerts
+		}
+	}
+	return nil
+}
+
+func (screen *watchScreen) setStatus(status string) {
+	screen.mutex.Lock()
+	screen.status = status
+	screen.mutex.Unlock()
+}
+
+func (screen *watchScreen) eventLoop() {
+	for {
+		event := termbox.PollEvent()
+		if event.Type != termbox.EventKey {
+			continue
+		}
+		screen.mutex.Lock()
+		changed := false
+		switch {
+		case screen.inputting || screen.removing:
+			changed = screen.handleTextInput(event)
+		case event.Key == termbox.KeyCtrlC || event.Ch == 'q':
+			screen.mutex.Unlock()
+			return
+		case event.Ch == 'a':
+			screen.inputting = true
+			screen.input = ""
+			screen.status = "Add symbol (Yahoo ticker optional as SYMBOL:YAHOO), Enter to confirm"
+		case event.Ch == 'd':
+			screen.removing = true
+			screen.input = ""
+			screen.status = "Remove symbol, Enter to confirm"
+		}
+		screen.mutex.Unlock()
+		if changed {
+			screen.resubscribe()
+		}
+		screen.render()
+	}
+}
+
+// handleTextInput reports whether the symbol list changed, so the caller can
+// resubscribe once the lock it's holding is released.
+func (screen *watchScreen) handleTextInput(event termbox.Event) bool {
+	switch event.Key {
+	case termbox.KeyEnter:
+		var changed bool
+		if screen.inputting {
+			changed = screen.addSymbol(screen.input)
+		} else {
+			changed = screen.removeSymbol(screen.input)
+		}
+		screen.inputting = false
+		screen.removing = false
+		screen.input = ""
+		return changed
+	case termbox.KeyEsc:
+		screen.inputting = false
+		screen.removing = false
+		screen.input = ""
+		screen.status = "Cancelled"
+	case termbox.KeyBackspace, termbox.KeyBackspace2:
+		if len(screen.input) > 0 {
+			screen.input = screen.input[:len(screen.input)-1]
+		}
+	case termbox.KeySpace:
+		screen.input += " "
+	default:
+		if event.Ch != 0 {
+			screen.input += string(event.Ch)
+		}
+	}
+	return false
+}
+
+// addSymbol and removeSymbol assume the caller already holds screen.mutex.
+func (screen *watchScreen) addSymbol(spec string) bool {
+	parts := strings.SplitN(strings.TrimSpace(spec), ":", 2)
+	if parts[0] == "" {
+		screen.status = "No symbol given"
+		return false
+	}
+	symbol := ScreenerSymbol{Symbol: strings.ToUpper(parts[0])}
+	if len(parts) == 2 {
+		symbol.Yahoo = parts[1]
+	}
+	configuration.Symbols = append(configuration.Symbols, symbol)
+	screen.persist()
+	screen.status = fmt.Sprintf("Added %s", symbol.Symbol)
+	return true
+}
+
+func (screen *watchScreen) removeSymbol(name string) bool {
+	target := strings.ToUpper(strings.TrimSpace(name))
+	for i, symbol := range configuration.Symbols {
+		if symbol.Symbol == target {
+			configuration.Symbols = append(configuration.Symbols[:i], configuration.Symbols[i+1:]...)
+			screen.persist()
+			screen.status = fmt.Sprintf("Removed %s", target)
+			return true
+		}
+	}
+	screen.status = fmt.Sprintf("Symbol %s not found", target)
+	return false
+}
+
+func (screen *watchScreen) persist() {
+	data, err := yaml.Marshal(configuration)
+	if err != nil {
+		screen.status = fmt.Sprintf("Failed to serialize configuration: %v", err)
+		return
+	}
+	if err := os.WriteFile(configurationPath, data, 0644); err != nil {
+		screen.status = fmt.Sprintf("Failed to persist configuration: %v", err)
+	}
+}
+
+func (screen *watchScreen) render() {
+	screen.mutex.Lock()
+	defer screen.mutex.Unlock()
+	termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+	row := 0
+	writeLine(row, "Live screener (a: add, d: remove, q: quit)")
+	row += 2
+	writeLine(row, fmt.Sprintf("%-8s %10s %10s %10s %-10s", "Symbol", "Yes", "No", "Change", "Provider"))
+	row++
+	for _, data := range screen.symbols {
+		writeLine(row, fmt.Sprintf("%-8s %10s %10s %+9.2f%% %-10s", data.symbol, decimalOrNA(data.yes), decimalOrNA(data.no), data.change, data.provider))
+		row++
+	}
+	row++
+	if screen.inputting || screen.removing {
+		writeLine(row, "> "+screen.input)
+		row++
+	}
+	if screen.status != "" {
+		writeLine(row, screen.status)
+	}
+	termbox.Flush()
+}
+
+func writeLine(row int, text string) {
+	for col, r := range text {
+		termbox.SetCell(col, row, r, termbox.ColorDefault, termbox.ColorDefault)
+	}
+}